@@ -0,0 +1,190 @@
+// Package webhooks translates inbound code host webhook payloads into
+// campaigns ChangesetEvents, so that a changeset's state can be updated
+// within seconds of an event happening on the code host instead of waiting
+// for the next sync.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+)
+
+// ChangesetEventStore is the subset of the campaigns store a webhook
+// handler needs: resolving the repo-scoped pull request ID to a tracked
+// changeset, persisting newly observed events deduplicated by Key, and
+// recomputing a changeset's merge state once its events change.
+type ChangesetEventStore interface {
+	// GetChangesetIDByExternalID returns the internal ID of the changeset
+	// tracking the pull request with the given ID on the given external
+	// service, or sql.ErrNoRows if it isn't tracked by any campaign.
+	GetChangesetIDByExternalID(ctx context.Context, externalServiceID, externalID string) (int64, error)
+	// UpsertChangesetEvent inserts event, or is a no-op if an event with
+	// the same ChangesetID and Key already exists. It reports whether the
+	// event was newly inserted.
+	UpsertChangesetEvent(ctx context.Context, event *cmpgn.ChangesetEvent) (inserted bool, err error)
+	// RecomputeMergeCommit recomputes and persists the ExternalState and
+	// merge commit of the changeset with the given ID from its events.
+	RecomputeMergeCommit(ctx context.Context, changesetID int64) error
+}
+
+// bitbucketServerSignatureHeader is the header Bitbucket Server sends the
+// HMAC-SHA256 signature of the request body in, keyed with the webhook's
+// configured shared secret.
+const bitbucketServerSignatureHeader = "X-Hub-Signature"
+
+// bitbucketServerEventKeys maps the Bitbucket Server webhook `eventKey`
+// values we act on to the ChangesetEventKind they translate into.
+var bitbucketServerEventKeys = map[string]cmpgn.ChangesetEventKind{
+	"pr:merged":            cmpgn.ChangesetEventKindBitbucketServerMerged,
+	"pr:opened":            cmpgn.ChangesetEventKindBitbucketServerOpened,
+	"pr:reviewer:approved": cmpgn.ChangesetEventKindBitbucketServerApproved,
+	"pr:comment:added":     cmpgn.ChangesetEventKindBitbucketServerCommented,
+}
+
+// BitbucketServerWebhook handles incoming Bitbucket Server webhook
+// requests for pull request events and turns them into campaigns
+// ChangesetEvents, bypassing the usual 8-minute polling sync.
+type BitbucketServerWebhook struct {
+	Store ChangesetEventStore
+	// ExternalServiceID identifies the Bitbucket Server external service
+	// this webhook's events belong to.
+	ExternalServiceID string
+	// Secret is the shared secret configured on the Bitbucket Server side
+	// for this webhook.
+	Secret string
+}
+
+type bitbucketServerPayload struct {
+	EventKey string `json:"eventKey"`
+	Date     string `json:"date"`
+	Actor    struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	PullRequest struct {
+		ID int64 `json:"id"`
+	} `json:"pullRequest"`
+	Comment *struct {
+		ID int64 `json:"id"`
+	} `json:"comment"`
+}
+
+func (h *BitbucketServerWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusInternalServerError)
+		return
+	}
+
+	if err := h.verifySignature(r.Header.Get(bitbucketServerSignatureHeader), body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	var payload bitbucketServerPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		http.Error(w, "failed to unmarshal payload", http.StatusBadRequest)
+		return
+	}
+
+	kind, ok := bitbucketServerEventKeys[payload.EventKey]
+	if !ok {
+		// We don't act on this event, but acknowledge it anyway so
+		// Bitbucket Server doesn't keep retrying it.
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ctx := r.Context()
+	externalID := fmt.Sprintf("%d", payload.PullRequest.ID)
+	changesetID, err := h.Store.GetChangesetIDByExternalID(ctx, h.ExternalServiceID, externalID)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "resolving changeset").Error(), http.StatusNotFound)
+		return
+	}
+
+	event := h.toChangesetEvent(changesetID, kind, &payload)
+
+	inserted, err := h.Store.UpsertChangesetEvent(ctx, event)
+	if err != nil {
+		http.Error(w, errors.Wrap(err, "upserting changeset event").Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if inserted && kind == cmpgn.ChangesetEventKindBitbucketServerMerged {
+		if err := h.Store.RecomputeMergeCommit(ctx, changesetID); err != nil {
+			http.Error(w, errors.Wrap(err, "recomputing merge commit").Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// toChangesetEvent maps a Bitbucket Server webhook payload onto a
+// cmpgn.ChangesetEvent, deriving a Key that's stable across redeliveries of
+// the same event so the store can deduplicate it. The exception is a
+// non-comment event whose payload has no parseable date: its Key includes
+// the time of receipt, so each redelivery gets a different Key.
+func (h *BitbucketServerWebhook) toChangesetEvent(changesetID int64, kind cmpgn.ChangesetEventKind, p *bitbucketServerPayload) *cmpgn.ChangesetEvent {
+	when, err := time.Parse(time.RFC3339, p.Date)
+	if err != nil {
+		// Not every Bitbucket Server webhook payload includes a date;
+		// fall back to now rather than rejecting the event outright.
+		when = time.Now().UTC()
+	}
+
+	var key string
+	if p.Comment != nil {
+		key = fmt.Sprintf("%s:%d", p.EventKey, p.Comment.ID)
+	} else {
+		key = fmt.Sprintf("%s:%s:%d", p.EventKey, p.Actor.Name, when.Unix())
+	}
+
+	return &cmpgn.ChangesetEvent{
+		ChangesetID: changesetID,
+		Kind:        kind,
+		Key:         key,
+		CreatedAt:   when,
+		UpdatedAt:   when,
+		Metadata: &bitbucketserver.Activity{
+			Action: p.EventKey,
+			User:   struct{ Name string }{Name: p.Actor.Name},
+		},
+	}
+}
+
+// verifySignature checks that header carries the hex-encoded HMAC-SHA256
+// of body, keyed with h.Secret, as sent by Bitbucket Server in the
+// X-Hub-Signature header (prefixed with "sha256=").
+func (h *BitbucketServerWebhook) verifySignature(header string, body []byte) error {
+	if h.Secret == "" {
+		return errors.New("no webhook secret configured")
+	}
+
+	sig := strings.TrimPrefix(header, "sha256=")
+	if sig == "" {
+		return errors.New("missing " + bitbucketServerSignatureHeader + " header")
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(sig), []byte(expected)) {
+		return errors.New("invalid webhook signature")
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+func TestBitbucketServerWebhookVerifySignature(t *testing.T) {
+	body := []byte(`{"eventKey":"pr:merged"}`)
+	secret := "s3cr3t"
+
+	sign := func(secret string, body []byte) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		wantErr bool
+	}{
+		{
+			name:   "valid signature",
+			secret: secret,
+			header: sign(secret, body),
+		},
+		{
+			name:    "invalid signature",
+			secret:  secret,
+			header:  sign("wrong-secret", body),
+			wantErr: true,
+		},
+		{
+			name:    "missing header",
+			secret:  secret,
+			header:  "",
+			wantErr: true,
+		},
+		{
+			name:    "no webhook secret configured",
+			secret:  "",
+			header:  sign(secret, body),
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			h := &BitbucketServerWebhook{Secret: tc.secret}
+			err := h.verifySignature(tc.header, body)
+			if tc.wantErr && err == nil {
+				t.Fatal("want error, got nil")
+			}
+			if !tc.wantErr && err != nil {
+				t.Fatalf("want no error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBitbucketServerWebhookToChangesetEvent(t *testing.T) {
+	h := &BitbucketServerWebhook{}
+
+	t.Run("comment event derives key from comment ID", func(t *testing.T) {
+		p := &bitbucketServerPayload{
+			EventKey: "pr:comment:added",
+			Date:     "2019-10-02T12:00:00Z",
+			Comment:  &struct {
+				ID int64 `json:"id"`
+			}{ID: 42},
+		}
+
+		ev := h.toChangesetEvent(1, cmpgn.ChangesetEventKindBitbucketServerCommented, p)
+
+		if have, want := ev.Key, "pr:comment:added:42"; have != want {
+			t.Fatalf("have key %q, want %q", have, want)
+		}
+	})
+
+	t.Run("non-comment event derives key from actor and timestamp", func(t *testing.T) {
+		p := &bitbucketServerPayload{
+			EventKey: "pr:opened",
+			Date:     "2019-10-02T12:00:00Z",
+		}
+		p.Actor.Name = "alice"
+
+		ev := h.toChangesetEvent(1, cmpgn.ChangesetEventKindBitbucketServerOpened, p)
+
+		if have, want := ev.Key, "pr:opened:alice:1570017600"; have != want {
+			t.Fatalf("have key %q, want %q", have, want)
+		}
+		if ev.Kind != cmpgn.ChangesetEventKindBitbucketServerOpened {
+			t.Fatalf("have kind %q, want %q", ev.Kind, cmpgn.ChangesetEventKindBitbucketServerOpened)
+		}
+	})
+
+	t.Run("missing date falls back to now rather than erroring", func(t *testing.T) {
+		p := &bitbucketServerPayload{EventKey: "pr:merged"}
+
+		ev := h.toChangesetEvent(1, cmpgn.ChangesetEventKindBitbucketServerMerged, p)
+
+		if ev.CreatedAt.IsZero() {
+			t.Fatal("want non-zero CreatedAt fallback, got zero value")
+		}
+	})
+}
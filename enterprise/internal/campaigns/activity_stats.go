@@ -0,0 +1,199 @@
+package campaigns
+
+import (
+	"sort"
+	"time"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// ActivityStats summarizes the changeset activity observed in a stream of
+// ChangesetEvents over a [from, to) interval, computed with a single walk
+// over the events so the GraphQL layer can render campaign burndown charts
+// without re-querying the database.
+//
+// Since campaigns only ever track changesets that are already open on the
+// code host, a changeset is considered "opened" the first time it appears
+// in the event stream; there is no dedicated "opened" event kind to key
+// off of.
+type ActivityStats struct {
+	OpenedCount int
+	MergedCount int
+	ClosedCount int
+
+	// Authors is the set of code host usernames that authored at least one
+	// of the events in the interval.
+	Authors map[string]struct{}
+
+	// Days is sorted by Day ascending and has one entry per day in the
+	// interval on which a changeset was opened or merged.
+	Days []*ActivityStatsDay
+
+	// openedMergedCount and activeCount count, among the changesets opened
+	// in the interval, those merged in it and those neither merged nor
+	// closed in it.
+	openedMergedCount int
+	activeCount       int
+}
+
+// ActivityStatsDay is the opened/merged counts for a single UTC day.
+type ActivityStatsDay struct {
+	Day    time.Time
+	Opened int
+	Merged int
+}
+
+// MergedPRPerc returns the percentage of opened changesets that ended up
+// merged, or 0 if none were opened.
+func (s *ActivityStats) MergedPRPerc() float64 {
+	if s.OpenedCount == 0 {
+		return 0
+	}
+	return float64(s.openedMergedCount) / float64(s.OpenedCount) * 100
+}
+
+// ActiveChangesetCount returns the number of changesets that were opened in
+// the interval and are neither merged nor closed.
+func (s *ActivityStats) ActiveChangesetCount() int {
+	return s.activeCount
+}
+
+// changesetActivity tracks the single opened/merged/closed state a
+// changeset settles into over the interval, so that a merge event and a
+// close event recorded for the same changeset count once rather than
+// twice.
+type changesetActivity struct {
+	openedAt time.Time
+	mergedAt *time.Time
+	closedAt *time.Time
+}
+
+// ActivityStats walks es once, in ascending UpdatedAt order, and returns the
+// opened/merged/closed counts, unique-author count, and per-day opened vs
+// merged buckets for changeset state changes that fall in [from, to).
+//
+// Each changeset's open time is established from its earliest event in es
+// as a whole, not just the events inside [from, to) — a changeset opened
+// long before the interval but merged or commented on during it must not
+// be counted as newly opened, so es is expected to carry a changeset's
+// complete event history rather than a pre-filtered slice.
+func (es ChangesetEvents) ActivityStats(from, to time.Time) *ActivityStats {
+	sorted := make(ChangesetEvents, len(es))
+	copy(sorted, es)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].UpdatedAt.Before(sorted[j].UpdatedAt) })
+
+	inWindow := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(to)
+	}
+
+	stats := &ActivityStats{Authors: map[string]struct{}{}}
+	changesets := map[int64]*changesetActivity{}
+
+	for _, e := range sorted {
+		c, ok := changesets[e.ChangesetID]
+		if !ok {
+			// The first event we see for a changeset, across its entire
+			// history, is our best proxy for when it was opened.
+			c = &changesetActivity{openedAt: e.UpdatedAt}
+			changesets[e.ChangesetID] = c
+		}
+
+		switch {
+		case isMergeKind(e.Kind):
+			updatedAt := e.UpdatedAt
+			c.mergedAt = &updatedAt
+		case isCloseKind(e.Kind):
+			updatedAt := e.UpdatedAt
+			c.closedAt = &updatedAt
+		}
+
+		if inWindow(e.UpdatedAt) {
+			if author := eventAuthor(e); author != "" {
+				stats.Authors[author] = struct{}{}
+			}
+		}
+	}
+
+	days := map[time.Time]*ActivityStatsDay{}
+	dayFor := func(t time.Time) *ActivityStatsDay {
+		day := t.UTC().Truncate(24 * time.Hour)
+		d, ok := days[day]
+		if !ok {
+			d = &ActivityStatsDay{Day: day}
+			days[day] = d
+		}
+		return d
+	}
+
+	for _, c := range changesets {
+		opened := inWindow(c.openedAt)
+		if opened {
+			stats.OpenedCount++
+			dayFor(c.openedAt).Opened++
+		}
+
+		switch {
+		case c.mergedAt != nil && inWindow(*c.mergedAt):
+			stats.MergedCount++
+			dayFor(*c.mergedAt).Merged++
+			if opened {
+				stats.openedMergedCount++
+			}
+		case c.closedAt != nil && inWindow(*c.closedAt):
+			stats.ClosedCount++
+		case opened:
+			stats.activeCount++
+		}
+	}
+
+	stats.Days = make([]*ActivityStatsDay, 0, len(days))
+	for _, d := range days {
+		stats.Days = append(stats.Days, d)
+	}
+	sort.Slice(stats.Days, func(i, j int) bool { return stats.Days[i].Day.Before(stats.Days[j].Day) })
+
+	return stats
+}
+
+func isMergeKind(k cmpgn.ChangesetEventKind) bool {
+	switch k {
+	case cmpgn.ChangesetEventKindGitHubMerged,
+		cmpgn.ChangesetEventKindBitbucketServerMerged,
+		cmpgn.ChangesetEventKindGitLabMerged:
+		return true
+	}
+	return false
+}
+
+func isCloseKind(k cmpgn.ChangesetEventKind) bool {
+	switch k {
+	case cmpgn.ChangesetEventKindGitHubClosed,
+		cmpgn.ChangesetEventKindBitbucketServerDeclined,
+		cmpgn.ChangesetEventKindGitLabClosed:
+		return true
+	}
+	return false
+}
+
+// eventAuthor returns the code host username that triggered ev, or the
+// empty string if it can't be determined from ev's metadata.
+func eventAuthor(ev *cmpgn.ChangesetEvent) string {
+	switch m := ev.Metadata.(type) {
+	case *github.LabelEvent:
+		return m.Actor.Login
+	case *github.MergedEvent:
+		return m.Actor.Login
+	case *github.PullRequestReviewComment:
+		return m.Author.Login
+	case *bitbucketserver.Activity:
+		return m.User.Name
+	case *gitlab.Note:
+		return m.Author.Username
+	case *gitlab.Approval:
+		return m.Author.Username
+	}
+	return ""
+}
@@ -0,0 +1,51 @@
+package campaigns
+
+import (
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
+)
+
+func init() {
+	RegisterEventReducer(bitbucketServerReducer{})
+}
+
+// bitbucketServerReducer reduces Bitbucket Server pull request activities
+// into Changeset derived state.
+type bitbucketServerReducer struct{}
+
+func (bitbucketServerReducer) Kinds() []cmpgn.ChangesetEventKind {
+	return []cmpgn.ChangesetEventKind{
+		cmpgn.ChangesetEventKindBitbucketServerApproved,
+		cmpgn.ChangesetEventKindBitbucketServerUnapproved,
+		cmpgn.ChangesetEventKindBitbucketServerCommented,
+		cmpgn.ChangesetEventKindBitbucketServerMerged,
+		cmpgn.ChangesetEventKindBitbucketServerDeclined,
+		cmpgn.ChangesetEventKindBitbucketServerOpened,
+	}
+}
+
+func (bitbucketServerReducer) ReduceLabels(prev []cmpgn.ChangesetLabel, ev *cmpgn.ChangesetEvent) []cmpgn.ChangesetLabel {
+	// Bitbucket Server doesn't support labels on pull requests, so there's
+	// nothing to reduce.
+	return prev
+}
+
+func (bitbucketServerReducer) MergeCommit(ev *cmpgn.ChangesetEvent) string {
+	m, ok := ev.Metadata.(*bitbucketserver.Activity)
+	if !ok {
+		return ""
+	}
+	if m.Commit != nil && m.Commit.ID != "" {
+		return m.Commit.ID
+	}
+	return ""
+}
+
+func (bitbucketServerReducer) SeedLabels(c *cmpgn.Changeset) ([]cmpgn.ChangesetLabel, bool) {
+	if _, ok := c.Metadata.(*bitbucketserver.PullRequest); !ok {
+		return nil, false
+	}
+	// Bitbucket Server doesn't support labels on pull requests, so there's
+	// nothing to seed, but we do recognize the code host.
+	return nil, true
+}
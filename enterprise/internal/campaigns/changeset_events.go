@@ -0,0 +1,85 @@
+package campaigns
+
+import (
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// ChangesetEvents is a collection of ChangesetEvents that can be reduced
+// into derived state about a Changeset, such as its current labels or the
+// SHA of the commit it was merged at. The actual per-event reduction is
+// delegated to the EventReducer registered for each event's Kind, so that
+// adding a code host only requires registering a new reducer rather than
+// editing the methods below.
+type ChangesetEvents []*cmpgn.ChangesetEvent
+
+// UpdateLabelsSince returns the current labels of the changeset, computed
+// by applying every label-changing event that happened after the
+// changeset's own UpdatedAt timestamp on top of the labels already present
+// in the changeset's Metadata.
+func (es ChangesetEvents) UpdateLabelsSince(c *cmpgn.Changeset) []cmpgn.ChangesetLabel {
+	if c == nil {
+		return nil
+	}
+
+	var current []cmpgn.ChangesetLabel
+	var recognized bool
+
+	for _, r := range reducers {
+		if labels, ok := r.SeedLabels(c); ok {
+			current, recognized = labels, true
+			break
+		}
+	}
+	if !recognized {
+		// No registered reducer recognizes c's code host, so there's
+		// nothing to seed from or reduce.
+		return nil
+	}
+
+	since := c.UpdatedAt
+	for _, e := range es {
+		if !e.UpdatedAt.After(since) {
+			continue
+		}
+		if r, ok := eventReducers[e.Kind]; ok {
+			current = r.ReduceLabels(current, e)
+		}
+	}
+
+	return current
+}
+
+func applyLabel(labels []cmpgn.ChangesetLabel, name, color, description string, removed bool) []cmpgn.ChangesetLabel {
+	if removed {
+		for i, l := range labels {
+			if l.Name == name {
+				return append(labels[:i], labels[i+1:]...)
+			}
+		}
+		return labels
+	}
+
+	for _, l := range labels {
+		if l.Name == name {
+			return labels
+		}
+	}
+	return append(labels, cmpgn.ChangesetLabel{Name: name, Color: color, Description: description})
+}
+
+// FindMergeCommitID returns the commit SHA of the merge commit, if the
+// events contain one for the changeset's code host. It returns the empty
+// string if the changeset hasn't been merged yet.
+func (es ChangesetEvents) FindMergeCommitID() string {
+	for _, e := range es {
+		r, ok := eventReducers[e.Kind]
+		if !ok {
+			continue
+		}
+		if sha := r.MergeCommit(e); sha != "" {
+			return sha
+		}
+	}
+
+	return ""
+}
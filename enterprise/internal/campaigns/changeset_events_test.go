@@ -125,7 +125,7 @@ func TestFindMergeCommitID(t *testing.T) {
 		return &cmpgn.ChangesetEvent{
 			ID:          1,
 			ChangesetID: 1,
-			Kind:        cmpgn.ChangesetEventKindBitbucketServerMerged,
+			Kind:        cmpgn.ChangesetEventKindGitHubMerged,
 			Key:         "key",
 			CreatedAt:   now,
 			UpdatedAt:   now,
@@ -217,3 +217,151 @@ func TestFindMergeCommitID(t *testing.T) {
 		})
 	}
 }
+
+func TestActivityStats(t *testing.T) {
+	day := func(offset int) time.Time {
+		return time.Date(2019, 10, 1+offset, 12, 0, 0, 0, time.UTC)
+	}
+
+	githubMerge := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindGitHubMerged,
+			UpdatedAt:   when,
+			Metadata: &github.MergedEvent{
+				Actor:  github.Actor{Login: actor},
+				Commit: github.Commit{OID: "deadbeef"},
+			},
+		}
+	}
+	githubComment := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindGitHubCommented,
+			UpdatedAt:   when,
+			Metadata:    &github.PullRequestReviewComment{Author: github.Actor{Login: actor}},
+		}
+	}
+	bitbucketApproved := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindBitbucketServerApproved,
+			UpdatedAt:   when,
+			Metadata: &bitbucketserver.Activity{
+				User: struct{ Name string }{Name: actor},
+			},
+		}
+	}
+	bitbucketDeclined := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindBitbucketServerDeclined,
+			UpdatedAt:   when,
+			Metadata: &bitbucketserver.Activity{
+				User: struct{ Name string }{Name: actor},
+			},
+		}
+	}
+
+	events := ChangesetEvents{
+		// Changeset 1: opened on day 0, merged on day 2 by a GitHub PR.
+		githubComment(1, "alice", day(0)),
+		githubMerge(1, "alice", day(2)),
+		// Changeset 2: opened and approved on day 1 via Bitbucket Server,
+		// never merged or declined.
+		bitbucketApproved(2, "bob", day(1)),
+		// Changeset 3: opened and declined on day 1 via Bitbucket Server.
+		bitbucketDeclined(3, "alice", day(1)),
+	}
+
+	stats := events.ActivityStats(day(0), day(3))
+
+	if have, want := stats.OpenedCount, 3; have != want {
+		t.Errorf("OpenedCount: have %d, want %d", have, want)
+	}
+	if have, want := stats.MergedCount, 1; have != want {
+		t.Errorf("MergedCount: have %d, want %d", have, want)
+	}
+	if have, want := stats.ClosedCount, 1; have != want {
+		t.Errorf("ClosedCount: have %d, want %d", have, want)
+	}
+	if have, want := len(stats.Authors), 2; have != want {
+		t.Errorf("len(Authors): have %d, want %d", have, want)
+	}
+	if have, want := stats.ActiveChangesetCount(), 1; have != want {
+		t.Errorf("ActiveChangesetCount: have %d, want %d", have, want)
+	}
+	if have, want := stats.MergedPRPerc(), float64(1)/float64(3)*100; have != want {
+		t.Errorf("MergedPRPerc: have %f, want %f", have, want)
+	}
+
+	wantDays := []struct {
+		day    time.Time
+		opened int
+		merged int
+	}{
+		{day(0).UTC().Truncate(24 * time.Hour), 1, 0},
+		{day(1).UTC().Truncate(24 * time.Hour), 2, 0},
+		{day(2).UTC().Truncate(24 * time.Hour), 0, 1},
+	}
+	if have, want := len(stats.Days), len(wantDays); have != want {
+		t.Fatalf("len(Days): have %d, want %d", have, want)
+	}
+	for i, w := range wantDays {
+		have := stats.Days[i]
+		if !have.Day.Equal(w.day) || have.Opened != w.opened || have.Merged != w.merged {
+			t.Errorf("Days[%d]: have %+v, want day=%s opened=%d merged=%d", i, have, w.day, w.opened, w.merged)
+		}
+	}
+}
+
+func TestActivityStatsOpenedBeforeWindow(t *testing.T) {
+	day := func(offset int) time.Time {
+		return time.Date(2019, 10, 1+offset, 12, 0, 0, 0, time.UTC)
+	}
+	githubMerge := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindGitHubMerged,
+			UpdatedAt:   when,
+			Metadata: &github.MergedEvent{
+				Actor:  github.Actor{Login: actor},
+				Commit: github.Commit{OID: "deadbeef"},
+			},
+		}
+	}
+	githubComment := func(changesetID int64, actor string, when time.Time) *cmpgn.ChangesetEvent {
+		return &cmpgn.ChangesetEvent{
+			ChangesetID: changesetID,
+			Kind:        cmpgn.ChangesetEventKindGitHubCommented,
+			UpdatedAt:   when,
+			Metadata:    &github.PullRequestReviewComment{Author: github.Actor{Login: actor}},
+		}
+	}
+
+	// Changeset 1 was opened and commented on well before the window, and
+	// only merged inside it: it must count towards MergedCount, but not
+	// towards OpenedCount, since its true open time is outside [from, to).
+	events := ChangesetEvents{
+		githubComment(1, "alice", day(-10)),
+		githubMerge(1, "alice", day(1)),
+	}
+
+	stats := events.ActivityStats(day(0), day(3))
+
+	if have, want := stats.OpenedCount, 0; have != want {
+		t.Errorf("OpenedCount: have %d, want %d", have, want)
+	}
+	if have, want := stats.MergedCount, 1; have != want {
+		t.Errorf("MergedCount: have %d, want %d", have, want)
+	}
+	if have, want := len(stats.Days), 1; have != want {
+		t.Fatalf("len(Days): have %d, want %d", have, want)
+	}
+	if have, want := stats.Days[0].Opened, 0; have != want {
+		t.Errorf("Days[0].Opened: have %d, want %d", have, want)
+	}
+	if have, want := stats.Days[0].Merged, 1; have != want {
+		t.Errorf("Days[0].Merged: have %d, want %d", have, want)
+	}
+}
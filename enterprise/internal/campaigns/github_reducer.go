@@ -0,0 +1,55 @@
+package campaigns
+
+import (
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+)
+
+func init() {
+	RegisterEventReducer(githubReducer{})
+}
+
+// githubReducer reduces GitHub pull request timeline events into Changeset
+// derived state.
+type githubReducer struct{}
+
+func (githubReducer) Kinds() []cmpgn.ChangesetEventKind {
+	return []cmpgn.ChangesetEventKind{
+		cmpgn.ChangesetEventKindGitHubLabeled,
+		cmpgn.ChangesetEventKindGitHubUnlabeled,
+		cmpgn.ChangesetEventKindGitHubMerged,
+	}
+}
+
+func (githubReducer) ReduceLabels(prev []cmpgn.ChangesetLabel, ev *cmpgn.ChangesetEvent) []cmpgn.ChangesetLabel {
+	e, ok := ev.Metadata.(*github.LabelEvent)
+	if !ok {
+		return prev
+	}
+	return applyLabel(prev, e.Label.Name, e.Label.Color, e.Label.Description, e.Removed)
+}
+
+func (githubReducer) MergeCommit(ev *cmpgn.ChangesetEvent) string {
+	e, ok := ev.Metadata.(*github.MergedEvent)
+	if !ok {
+		return ""
+	}
+	return e.Commit.OID
+}
+
+func (githubReducer) SeedLabels(c *cmpgn.Changeset) ([]cmpgn.ChangesetLabel, bool) {
+	pr, ok := c.Metadata.(*github.PullRequest)
+	if !ok {
+		return nil, false
+	}
+
+	labels := make([]cmpgn.ChangesetLabel, len(pr.Labels.Nodes))
+	for i, l := range pr.Labels.Nodes {
+		labels[i] = cmpgn.ChangesetLabel{
+			Name:        l.Name,
+			Color:       l.Color,
+			Description: l.Description,
+		}
+	}
+	return labels, true
+}
@@ -0,0 +1,112 @@
+package campaigns
+
+import (
+	"fmt"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+// SyncGitLabChangesetEvents fetches the current notes, approvals, label
+// history, and state of the merge request backing changeset from GitLab
+// and maps them onto the ChangesetEvent model used by every other code
+// host, so that ChangesetEvents.UpdateLabelsSince and
+// ChangesetEvents.FindMergeCommitID can reduce over them the same way they
+// do for GitHub and Bitbucket Server events.
+func SyncGitLabChangesetEvents(client *gitlab.Client, changeset *cmpgn.Changeset) ([]*cmpgn.ChangesetEvent, error) {
+	mr, ok := changeset.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return nil, fmt.Errorf("changeset metadata is not a GitLab merge request: %T", changeset.Metadata)
+	}
+
+	// Fetch the merge request itself so that its state and merge commit are
+	// the current ones on GitLab rather than the ones last stored.
+	mr, err := client.GetMergeRequest(mr.ProjectID, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := client.GetMergeRequestNotes(mr.ProjectID, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := client.GetMergeRequestApprovals(mr.ProjectID, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	labelEvents, err := client.GetMergeRequestResourceLabelEvents(mr.ProjectID, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*cmpgn.ChangesetEvent, 0, len(notes)+len(approvals)+len(labelEvents)+1)
+
+	for _, n := range notes {
+		events = append(events, &cmpgn.ChangesetEvent{
+			ChangesetID: changeset.ID,
+			Kind:        cmpgn.ChangesetEventKindGitLabCommented,
+			Key:         fmt.Sprintf("note:%d", n.ID),
+			CreatedAt:   n.CreatedAt,
+			UpdatedAt:   n.UpdatedAt,
+			Metadata:    n,
+		})
+	}
+
+	for _, a := range approvals {
+		// The approvals endpoint doesn't tell us when an approval happened,
+		// so we stamp it with the merge request's own UpdatedAt rather than
+		// leaving it at the zero value, which would sort the approval
+		// before the merge request was ever opened.
+		a.CreatedAt = mr.UpdatedAt
+		events = append(events, &cmpgn.ChangesetEvent{
+			ChangesetID: changeset.ID,
+			Kind:        cmpgn.ChangesetEventKindGitLabApproved,
+			Key:         fmt.Sprintf("approval:%s", a.Author.Username),
+			CreatedAt:   a.CreatedAt,
+			UpdatedAt:   a.CreatedAt,
+			Metadata:    a,
+		})
+	}
+
+	for _, le := range labelEvents {
+		kind := cmpgn.ChangesetEventKindGitLabLabeled
+		if le.Action == "remove" {
+			kind = cmpgn.ChangesetEventKindGitLabUnlabeled
+		}
+		events = append(events, &cmpgn.ChangesetEvent{
+			ChangesetID: changeset.ID,
+			Kind:        kind,
+			Key:         fmt.Sprintf("label:%d", le.ID),
+			CreatedAt:   le.CreatedAt,
+			UpdatedAt:   le.CreatedAt,
+			Metadata:    &le.Label,
+		})
+	}
+
+	switch mr.State {
+	case "merged":
+		if mr.MergeCommitSHA != "" {
+			events = append(events, &cmpgn.ChangesetEvent{
+				ChangesetID: changeset.ID,
+				Kind:        cmpgn.ChangesetEventKindGitLabMerged,
+				Key:         fmt.Sprintf("merged:%s", mr.MergeCommitSHA),
+				CreatedAt:   mr.UpdatedAt,
+				UpdatedAt:   mr.UpdatedAt,
+				Metadata:    mr,
+			})
+		}
+	case "closed":
+		events = append(events, &cmpgn.ChangesetEvent{
+			ChangesetID: changeset.ID,
+			Kind:        cmpgn.ChangesetEventKindGitLabClosed,
+			Key:         fmt.Sprintf("closed:%d", mr.IID),
+			CreatedAt:   mr.UpdatedAt,
+			UpdatedAt:   mr.UpdatedAt,
+			Metadata:    mr,
+		})
+	}
+
+	return events, nil
+}
@@ -0,0 +1,54 @@
+package campaigns
+
+import (
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+)
+
+func init() {
+	RegisterEventReducer(gitlabReducer{})
+}
+
+// gitlabReducer reduces GitLab merge request notes and state into
+// Changeset derived state.
+type gitlabReducer struct{}
+
+func (gitlabReducer) Kinds() []cmpgn.ChangesetEventKind {
+	return []cmpgn.ChangesetEventKind{
+		cmpgn.ChangesetEventKindGitLabApproved,
+		cmpgn.ChangesetEventKindGitLabCommented,
+		cmpgn.ChangesetEventKindGitLabLabeled,
+		cmpgn.ChangesetEventKindGitLabUnlabeled,
+		cmpgn.ChangesetEventKindGitLabMerged,
+		cmpgn.ChangesetEventKindGitLabClosed,
+	}
+}
+
+func (gitlabReducer) ReduceLabels(prev []cmpgn.ChangesetLabel, ev *cmpgn.ChangesetEvent) []cmpgn.ChangesetLabel {
+	l, ok := ev.Metadata.(*gitlab.Label)
+	if !ok {
+		return prev
+	}
+	return applyLabel(prev, l.Name, l.Color, "", ev.Kind == cmpgn.ChangesetEventKindGitLabUnlabeled)
+}
+
+func (gitlabReducer) MergeCommit(ev *cmpgn.ChangesetEvent) string {
+	mr, ok := ev.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return ""
+	}
+	return mr.MergeCommitSHA
+}
+
+func (gitlabReducer) SeedLabels(c *cmpgn.Changeset) ([]cmpgn.ChangesetLabel, bool) {
+	mr, ok := c.Metadata.(*gitlab.MergeRequest)
+	if !ok {
+		return nil, false
+	}
+
+	labels := make([]cmpgn.ChangesetLabel, len(mr.Labels))
+	for i, name := range mr.Labels {
+		labels[i] = cmpgn.ChangesetLabel{Name: name}
+	}
+	return labels, true
+}
@@ -0,0 +1,56 @@
+package campaigns
+
+import (
+	"fmt"
+
+	cmpgn "github.com/sourcegraph/sourcegraph/internal/campaigns"
+)
+
+// EventReducer knows how to reduce the ChangesetEvents of a single code
+// host into the derived state tracked on a Changeset: its labels and the
+// commit SHA it was merged at. Code host packages register their
+// EventReducer at init time via RegisterEventReducer, so that adding
+// support for a new code host (GitLab, Gitea, Phabricator, ...) is a
+// matter of dropping in a new package rather than editing the reducers in
+// this package.
+type EventReducer interface {
+	// Kinds returns the ChangesetEventKinds this reducer knows how to
+	// handle. ReduceLabels and MergeCommit are only ever called with
+	// events of one of these kinds.
+	Kinds() []cmpgn.ChangesetEventKind
+
+	// ReduceLabels applies ev on top of prev, returning the new label set.
+	ReduceLabels(prev []cmpgn.ChangesetLabel, ev *cmpgn.ChangesetEvent) []cmpgn.ChangesetLabel
+
+	// MergeCommit returns the merge commit SHA recorded by ev, or the
+	// empty string if ev isn't a merge event.
+	MergeCommit(ev *cmpgn.ChangesetEvent) string
+
+	// SeedLabels returns the labels already present in the changeset's own
+	// metadata (as opposed to ones derived from events), and whether this
+	// reducer recognizes c's code host at all. UpdateLabelsSince uses this
+	// to find the right reducer to seed from without hardcoding a type
+	// switch over every code host's Metadata type.
+	SeedLabels(c *cmpgn.Changeset) (labels []cmpgn.ChangesetLabel, ok bool)
+}
+
+// eventReducers maps a ChangesetEventKind to the EventReducer registered to
+// handle it.
+var eventReducers = map[cmpgn.ChangesetEventKind]EventReducer{}
+
+// reducers lists every registered EventReducer once, in registration
+// order, for operations like SeedLabels that aren't keyed by event kind.
+var reducers []EventReducer
+
+// RegisterEventReducer registers r for every kind it declares via Kinds. It
+// panics if a reducer is already registered for one of those kinds, since
+// that would silently shadow it.
+func RegisterEventReducer(r EventReducer) {
+	reducers = append(reducers, r)
+	for _, kind := range r.Kinds() {
+		if _, exists := eventReducers[kind]; exists {
+			panic(fmt.Sprintf("campaigns: EventReducer already registered for kind %q", kind))
+		}
+		eventReducers[kind] = r
+	}
+}
@@ -0,0 +1,27 @@
+// Package campaignspb defines the messages for CampaignsService.
+//
+// These types are hand-written rather than generated by protoc-gen-go: the
+// build has no protoc/buf step yet, so campaigns.proto exists as the source
+// of truth to write code-gen against later, not as something this file was
+// produced from. Keep it in sync with campaigns.proto by hand until that
+// step exists.
+package campaignspb
+
+// Request carries a GraphQL query/mutation to CampaignsService.GraphQL.
+type Request struct {
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	VariablesJson []byte `protobuf:"bytes,2,opt,name=variables_json,json=variablesJson,proto3" json:"variables_json,omitempty"`
+}
+
+func (m *Request) Reset()         { *m = Request{} }
+func (m *Request) String() string { return "" }
+func (*Request) ProtoMessage()    {}
+
+// Response carries the raw JSON response of a GraphQL query/mutation.
+type Response struct {
+	Json []byte `protobuf:"bytes,1,opt,name=json,proto3" json:"json,omitempty"`
+}
+
+func (m *Response) Reset()         { *m = Response{} }
+func (m *Response) String() string { return "" }
+func (*Response) ProtoMessage()    {}
@@ -0,0 +1,33 @@
+// Hand-written gRPC client stub for CampaignsService, mirroring
+// campaigns.proto until a protoc-gen-go-grpc build step exists. Keep it in
+// sync with campaigns.proto by hand.
+
+package campaignspb
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// CampaignsClient is the client API for CampaignsService.
+type CampaignsClient interface {
+	GraphQL(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error)
+}
+
+type campaignsClient struct {
+	cc *grpc.ClientConn
+}
+
+// NewCampaignsClient returns a CampaignsClient backed by cc.
+func NewCampaignsClient(cc *grpc.ClientConn) CampaignsClient {
+	return &campaignsClient{cc}
+}
+
+func (c *campaignsClient) GraphQL(ctx context.Context, in *Request, opts ...grpc.CallOption) (*Response, error) {
+	out := new(Response)
+	if err := c.cc.Invoke(ctx, "/campaigns.CampaignsService/GraphQL", in, out, opts...); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package campaigns
+
+import "time"
+
+// A Changeset is a changeset on a code host that is tracked by Sourcegraph as
+// part of a Campaign.
+type Changeset struct {
+	ID                  int64
+	RepoID              int32
+	CampaignIDs         []int64
+	ExternalID          string
+	ExternalServiceType string
+	ExternalBranch      string
+	ExternalUpdatedAt   time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+
+	// Metadata is the code-host-specific representation of the changeset,
+	// e.g. *github.PullRequest, *bitbucketserver.PullRequest or
+	// *gitlab.MergeRequest.
+	Metadata interface{}
+}
+
+// ChangesetLabel represents a label applied to a Changeset on a code host.
+type ChangesetLabel struct {
+	Name        string
+	Color       string
+	Description string
+}
+
+// ChangesetEventKind defines the kind of a ChangesetEvent. The valid values
+// are the constants declared in this package.
+type ChangesetEventKind string
+
+// Valid ChangesetEvent kinds.
+const (
+	ChangesetEventKindGitHubCommented ChangesetEventKind = "githubCommented"
+	ChangesetEventKindGitHubLabeled   ChangesetEventKind = "githubLabeled"
+	ChangesetEventKindGitHubUnlabeled ChangesetEventKind = "githubUnlabeled"
+	ChangesetEventKindGitHubMerged    ChangesetEventKind = "githubMerged"
+	ChangesetEventKindGitHubClosed    ChangesetEventKind = "githubClosed"
+	ChangesetEventKindGitHubReviewed  ChangesetEventKind = "githubReviewed"
+
+	ChangesetEventKindBitbucketServerApproved   ChangesetEventKind = "bitbucketServerApproved"
+	ChangesetEventKindBitbucketServerUnapproved ChangesetEventKind = "bitbucketServerUnapproved"
+	ChangesetEventKindBitbucketServerCommented  ChangesetEventKind = "bitbucketServerCommented"
+	ChangesetEventKindBitbucketServerMerged     ChangesetEventKind = "bitbucketServerMerged"
+	ChangesetEventKindBitbucketServerDeclined   ChangesetEventKind = "bitbucketServerDeclined"
+	ChangesetEventKindBitbucketServerOpened     ChangesetEventKind = "bitbucketServerOpened"
+
+	// GitLab kinds. GitLab merge requests surface activity through a mix of
+	// resource label events (for label changes), notes (for comments and
+	// system notes such as approvals) and the merge request's own state, so
+	// the kinds below mirror the GitLab webhook/API vocabulary rather than
+	// GitHub's.
+	ChangesetEventKindGitLabApproved  ChangesetEventKind = "gitlabApproved"
+	ChangesetEventKindGitLabCommented ChangesetEventKind = "gitlabCommented"
+	ChangesetEventKindGitLabLabeled   ChangesetEventKind = "gitlabLabeled"
+	ChangesetEventKindGitLabUnlabeled ChangesetEventKind = "gitlabUnlabeled"
+	ChangesetEventKindGitLabMerged    ChangesetEventKind = "gitlabMerged"
+	ChangesetEventKindGitLabClosed    ChangesetEventKind = "gitlabClosed"
+)
+
+// ChangesetEvent is an event that happened on a Changeset on a code host,
+// e.g. a comment being added, a label changing or the changeset being
+// merged. Events are the source of truth from which a Changeset's derived
+// state (labels, approvals, merge status, ...) is computed.
+type ChangesetEvent struct {
+	ID          int64
+	ChangesetID int64
+	Kind        ChangesetEventKind
+	// Key is a deduplication key unique among events of the same Kind for a
+	// given changeset, derived from what the code host reports about the
+	// event (e.g. a comment or note ID) so that re-syncing the same event is
+	// a no-op.
+	Key       string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Metadata is the code-host-specific payload of the event, e.g.
+	// *github.LabelEvent or *bitbucketserver.Activity.
+	Metadata interface{}
+}
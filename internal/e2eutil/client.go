@@ -84,6 +84,11 @@ type Client struct {
 	csrfCookie    *http.Cookie
 	sessionCookie *http.Cookie
 
+	// oidc is set when the client was created via SignInOIDC and carries
+	// the OAuth2 token pair used to authenticate GraphQL requests instead
+	// of the session cookie.
+	oidc *oidcSession
+
 	userID string
 }
 
@@ -214,9 +219,16 @@ func (c *Client) GraphQL(token, query string, variables map[string]interface{},
 	if err != nil {
 		return err
 	}
-	if token != "" {
+	switch {
+	case token != "":
 		req.Header.Set("Authorization", fmt.Sprintf("token %s", token))
-	} else {
+	case c.oidc != nil:
+		accessToken, err := c.oidc.accessToken()
+		if err != nil {
+			return errors.Wrap(err, "refresh OIDC access token")
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	default:
 		// NOTE: We use this header to protect from CSRF attacks of HTTP API,
 		// see https://sourcegraph.com/github.com/sourcegraph/sourcegraph/-/blob/cmd/frontend/internal/cli/http.go#L41-42
 		req.Header.Set("X-Requested-With", "Sourcegraph")
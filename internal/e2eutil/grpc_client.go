@@ -0,0 +1,117 @@
+package e2eutil
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/sourcegraph/sourcegraph/internal/campaigns/campaignspb"
+)
+
+// GRPCClient is an authenticated client for a Sourcegraph user for doing
+// e2e testing, like Client, but talks to the campaigns gRPC API instead of
+// GraphQL-over-HTTP. It's meant for deployments that only expose the
+// internal gRPC port.
+type GRPCClient struct {
+	conn  *grpc.ClientConn
+	rpc   campaignspb.CampaignsClient
+	creds *grpcTokenCredentials
+}
+
+// NewGRPCClient dials the gRPC campaigns API at baseURL. If tlsConfig is
+// non-nil, the connection is secured with it; a tlsConfig with a RootCAs
+// pool lets tests pin the certificate of a self-signed or private-CA
+// instance. A nil tlsConfig dials an insecure connection, which should
+// only be used against a loopback or otherwise trusted instance.
+func NewGRPCClient(baseURL string, tlsConfig *tls.Config) (*GRPCClient, error) {
+	creds := &grpcTokenCredentials{requireTLS: tlsConfig != nil}
+
+	opts := []grpc.DialOption{grpc.WithPerRPCCredentials(creds)}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	conn, err := grpc.Dial(baseURL, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "dial")
+	}
+
+	return &GRPCClient{
+		conn:  conn,
+		rpc:   campaignspb.NewCampaignsClient(conn),
+		creds: creds,
+	}, nil
+}
+
+// Authenticate sets the bearer token attached to every subsequent RPC via
+// PerRPCCredentials, mirroring the token param of Client.GraphQL. It's
+// safe to call concurrently with GraphQL.
+func (c *GRPCClient) Authenticate(token string) {
+	c.creds.setToken(token)
+}
+
+// GraphQL executes a GraphQL query against the gRPC campaigns API the same
+// way Client.GraphQL does over HTTP. A nil target skips unmarshalling the
+// returned JSON response.
+func (c *GRPCClient) GraphQL(query string, variables map[string]interface{}, target interface{}) error {
+	variablesJSON, err := jsoniter.Marshal(variables)
+	if err != nil {
+		return errors.Wrap(err, "marshal variables")
+	}
+
+	resp, err := c.rpc.GraphQL(context.Background(), &campaignspb.Request{
+		Query:         query,
+		VariablesJson: variablesJSON,
+	})
+	if err != nil {
+		return errors.Wrap(err, "GraphQL RPC")
+	}
+
+	if target == nil {
+		return nil
+	}
+	return jsoniter.Unmarshal(resp.Json, target)
+}
+
+// Close tears down the underlying gRPC connection.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// grpcTokenCredentials implements credentials.PerRPCCredentials, attaching
+// a bearer token to every RPC. It's safe for concurrent use so that
+// Authenticate can rotate the token while GraphQL calls are in flight.
+type grpcTokenCredentials struct {
+	requireTLS bool
+
+	mu    sync.RWMutex
+	token string
+}
+
+func (c *grpcTokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	c.mu.RLock()
+	token := c.token
+	c.mu.RUnlock()
+
+	if token == "" {
+		return nil, nil
+	}
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+func (c *grpcTokenCredentials) RequireTransportSecurity() bool {
+	return c.requireTLS
+}
+
+func (c *grpcTokenCredentials) setToken(token string) {
+	c.mu.Lock()
+	c.token = token
+	c.mu.Unlock()
+}
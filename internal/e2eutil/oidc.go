@@ -0,0 +1,138 @@
+package e2eutil
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// oidcTokenResponse is the subset of an OAuth2 token endpoint response we
+// care about, shared by the initial password grant and subsequent
+// refresh_token grants.
+type oidcTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+}
+
+// oidcSession holds the OAuth2 token pair obtained from an OIDC issuer and
+// transparently refreshes the access token shortly before it expires. It is
+// safe for concurrent use.
+type oidcSession struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+
+	mu           sync.Mutex
+	access       string
+	refreshToken string
+	expiresAt    time.Time
+}
+
+// tokenEndpoint is the issuer's "/token" endpoint, as used by both the
+// password and refresh_token grants.
+func (s *oidcSession) tokenEndpoint() string {
+	return strings.TrimSuffix(s.issuer, "/") + "/token"
+}
+
+// accessToken returns a valid access token, transparently performing a
+// refresh_token grant if the current one is at or near expiry.
+func (s *oidcSession) accessToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Now().Before(s.expiresAt.Add(-30 * time.Second)) {
+		return s.access, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {s.refreshToken},
+		"client_id":     {s.clientID},
+		"client_secret": {s.clientSecret},
+	}
+	tok, err := requestOIDCToken(s.tokenEndpoint(), form)
+	if err != nil {
+		return "", errors.Wrap(err, "refresh token")
+	}
+
+	s.setToken(tok)
+	return s.access, nil
+}
+
+// setToken stores the token response on the session. Callers must hold
+// s.mu.
+func (s *oidcSession) setToken(tok *oidcTokenResponse) {
+	s.access = tok.AccessToken
+	if tok.RefreshToken != "" {
+		s.refreshToken = tok.RefreshToken
+	}
+	s.expiresAt = time.Now().Add(time.Duration(tok.ExpiresIn) * time.Second)
+}
+
+// requestOIDCToken POSTs form to the issuer's token endpoint and decodes
+// the resulting token response.
+func requestOIDCToken(tokenEndpoint string, form url.Values) (*oidcTokenResponse, error) {
+	resp, err := http.PostForm(tokenEndpoint, form)
+	if err != nil {
+		return nil, errors.Wrap(err, "post form")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("token endpoint returned status %d", resp.StatusCode)
+	}
+
+	var tok oidcTokenResponse
+	if err := jsoniter.NewDecoder(resp.Body).Decode(&tok); err != nil {
+		return nil, errors.Wrap(err, "decode token response")
+	}
+	return &tok, nil
+}
+
+// SignInOIDC performs the OAuth2 resource owner password credentials grant
+// against issuer to obtain an access/refresh token pair for username, then
+// returns an authenticated client as that user for doing e2e testing. Unlike
+// SignIn, the returned client's GraphQL calls are authenticated with a
+// bearer token that is transparently refreshed before it expires, so that
+// long-running e2e suites can exercise SSO-protected Sourcegraph instances
+// without re-authenticating between test cases.
+func SignInOIDC(baseURL, issuer, clientID, clientSecret, username, password string) (*Client, error) {
+	client, err := newClient(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "new client")
+	}
+
+	form := url.Values{
+		"grant_type":    {"password"},
+		"username":      {username},
+		"password":      {password},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+	}
+	tok, err := requestOIDCToken(strings.TrimSuffix(issuer, "/")+"/token", form)
+	if err != nil {
+		return nil, errors.Wrap(err, "request token")
+	}
+
+	session := &oidcSession{
+		issuer:       issuer,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+	}
+	session.setToken(tok)
+	client.oidc = session
+
+	userID, err := client.currentUserID()
+	if err != nil {
+		return nil, errors.Wrap(err, "get current user")
+	}
+	client.userID = userID
+
+	return client, nil
+}
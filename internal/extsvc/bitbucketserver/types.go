@@ -0,0 +1,43 @@
+package bitbucketserver
+
+import "time"
+
+// Commit is a minimal representation of a Bitbucket Server commit, as
+// embedded in pull request activities.
+type Commit struct {
+	ID        string
+	DisplayID string
+	Author    string
+	Message   string
+}
+
+// Activity is a Bitbucket Server pull request activity, e.g. an approval,
+// a comment or the pull request being merged.
+type Activity struct {
+	ID          int64
+	CreatedDate int64
+	User        struct {
+		Name string
+	}
+	Action  string
+	Commit  *Commit
+	Comment *struct {
+		ID   int64
+		Text string
+	}
+}
+
+// CreatedAt returns the time the activity was created at.
+func (a *Activity) CreatedAt() time.Time {
+	return time.Unix(a.CreatedDate/1000, 0)
+}
+
+// PullRequest is a Bitbucket Server pull request.
+type PullRequest struct {
+	ID          int64
+	Title       string
+	Description string
+	State       string
+	CreatedDate int64
+	UpdatedDate int64
+}
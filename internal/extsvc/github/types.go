@@ -0,0 +1,67 @@
+package github
+
+import "time"
+
+// Actor is a GitHub user or bot that triggered an event.
+type Actor struct {
+	AvatarURL string
+	Login     string
+	URL       string
+}
+
+// Label is a GitHub label, as applied to issues and pull requests.
+type Label struct {
+	Name        string
+	Color       string
+	Description string
+}
+
+// Commit is the commit information contained in GitHub pull request events.
+type Commit struct {
+	OID string
+}
+
+// LabelEvent is a GitHub issue/pull request timeline event for a label
+// being added or removed.
+type LabelEvent struct {
+	Actor     Actor
+	Label     Label
+	CreatedAt time.Time
+	// Removed is true when the label was removed rather than added.
+	Removed bool
+}
+
+// MergedEvent is a GitHub pull request timeline event recording that the
+// pull request was merged.
+type MergedEvent struct {
+	Actor        Actor
+	MergeRefName string
+	URL          string
+	Commit       Commit
+	CreatedAt    time.Time
+}
+
+// PullRequestReviewComment is a comment left as part of a pull request
+// review.
+type PullRequestReviewComment struct {
+	Author    Actor
+	Body      string
+	URL       string
+	Commit    Commit
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// PullRequest is a GitHub pull request.
+type PullRequest struct {
+	ID     string
+	Title  string
+	Body   string
+	State  string
+	URL    string
+	Labels struct {
+		Nodes []Label
+	}
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,105 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// Client access a GitLab API. A Client can have a personal access token,
+// which is used for authenticating with the GitLab instance it points to.
+type Client struct {
+	baseURL    *url.URL
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a new Client for the GitLab instance at baseURL,
+// authenticating with the given personal access token.
+func NewClient(baseURL *url.URL, token string) *Client {
+	return &Client{
+		baseURL:    baseURL,
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+func (c *Client) do(method, path string, result interface{}) error {
+	req, err := http.NewRequest(method, c.baseURL.String()+path, nil)
+	if err != nil {
+		return errors.Wrap(err, "new request")
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "do request")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("gitlab API returned status %d for %s", resp.StatusCode, path)
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// GetMergeRequest returns the merge request with the given IID in the given
+// project.
+func (c *Client) GetMergeRequest(projectID, iid int32) (*MergeRequest, error) {
+	var mr MergeRequest
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, iid)
+	if err := c.do("GET", path, &mr); err != nil {
+		return nil, errors.Wrap(err, "get merge request")
+	}
+	return &mr, nil
+}
+
+// GetMergeRequestNotes returns all notes (comments and system notes) left on
+// the merge request with the given IID in the given project.
+func (c *Client) GetMergeRequestNotes(projectID, iid int32) ([]*Note, error) {
+	var notes []*Note
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d/notes", projectID, iid)
+	if err := c.do("GET", path, &notes); err != nil {
+		return nil, errors.Wrap(err, "get merge request notes")
+	}
+	return notes, nil
+}
+
+// GetMergeRequestResourceLabelEvents returns the history of labels added to
+// and removed from the merge request with the given IID in the given
+// project.
+func (c *Client) GetMergeRequestResourceLabelEvents(projectID, iid int32) ([]*ResourceLabelEvent, error) {
+	var events []*ResourceLabelEvent
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d/resource_label_events", projectID, iid)
+	if err := c.do("GET", path, &events); err != nil {
+		return nil, errors.Wrap(err, "get merge request resource label events")
+	}
+	return events, nil
+}
+
+// GetMergeRequestApprovals returns the approvals given to the merge request
+// with the given IID in the given project.
+func (c *Client) GetMergeRequestApprovals(projectID, iid int32) ([]*Approval, error) {
+	var resp struct {
+		ApprovedBy []struct {
+			User Author `json:"user"`
+		} `json:"approved_by"`
+	}
+	path := fmt.Sprintf("/projects/%d/merge_requests/%d/approvals", projectID, iid)
+	if err := c.do("GET", path, &resp); err != nil {
+		return nil, errors.Wrap(err, "get merge request approvals")
+	}
+
+	approvals := make([]*Approval, 0, len(resp.ApprovedBy))
+	for _, a := range resp.ApprovedBy {
+		approvals = append(approvals, &Approval{Author: a.User})
+	}
+	return approvals, nil
+}
@@ -0,0 +1,62 @@
+package gitlab
+
+import "time"
+
+// MergeRequest is a GitLab merge request.
+type MergeRequest struct {
+	ID             int32     `json:"id"`
+	IID            int32     `json:"iid"`
+	ProjectID      int32     `json:"project_id"`
+	Title          string    `json:"title"`
+	Description    string    `json:"description"`
+	State          string    `json:"state"`
+	MergeStatus    string    `json:"merge_status"`
+	MergeCommitSHA string    `json:"merge_commit_sha"`
+	Labels         []string  `json:"labels"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Author is the GitLab user that authored a note or approval.
+type Author struct {
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	Username string `json:"username"`
+}
+
+// Note is a comment or system note left on a merge request. GitLab
+// represents both user comments and system events (label changes, state
+// transitions, ...) as notes, distinguished by the System field.
+type Note struct {
+	ID        int32     `json:"id"`
+	Body      string    `json:"body"`
+	Author    Author    `json:"author"`
+	System    bool      `json:"system"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Approval records that a user approved a merge request. GitLab's approvals
+// endpoint doesn't report when an approval happened, so CreatedAt is left
+// for the caller to fill in (e.g. from the merge request's own UpdatedAt).
+type Approval struct {
+	Author    Author
+	CreatedAt time.Time
+}
+
+// Label is a GitLab project label, as applied to merge requests.
+type Label struct {
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+// ResourceLabelEvent records a label being added to or removed from a
+// merge request. GitLab exposes these as a separate "resource label
+// events" endpoint rather than folding them into notes.
+type ResourceLabelEvent struct {
+	ID int32 `json:"id"`
+	// Action is either "add" or "remove".
+	Action    string    `json:"action"`
+	Label     Label     `json:"label"`
+	CreatedAt time.Time `json:"created_at"`
+}